@@ -0,0 +1,120 @@
+// Copyright 2013 Eric Myhre
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iox
+
+import (
+	"io"
+	"testing"
+)
+
+func TestReaderFromChanByteSliceChunked(t *testing.T) {
+	ch := make(chan []byte, 2)
+	ch <- []byte("hello")
+	ch <- []byte("world")
+	close(ch)
+
+	got, err := io.ReadAll(ReaderFromChanByteSliceChunked(ch, nil))
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	want := "5\r\nhello\r\n5\r\nworld\r\n0\r\n\r\n"
+	if string(got) != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestReaderFromChanByteSliceChunkedWithTrailer(t *testing.T) {
+	ch := make(chan []byte, 1)
+	ch <- []byte("hi")
+	close(ch)
+
+	got, err := io.ReadAll(ReaderFromChanByteSliceChunked(ch, &ChunkedOptions{
+		Trailer: map[string]string{"X-Checksum": "abc123"},
+	}))
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	want := "2\r\nhi\r\n0\r\nX-Checksum: abc123\r\n\r\n"
+	if string(got) != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+// TestReaderFromChanByteSliceChunkedSkipsEmptyMessages guards against
+// regressing into emitting "0\r\n\r\n" for a zero-length message, which is
+// indistinguishable from the stream's real terminating trailer and would
+// cause a conformant decoder to silently drop everything sent afterward.
+func TestReaderFromChanByteSliceChunkedSkipsEmptyMessages(t *testing.T) {
+	ch := make(chan []byte, 3)
+	ch <- []byte("before")
+	ch <- []byte(nil)
+	ch <- []byte("after")
+	close(ch)
+
+	got, err := io.ReadAll(ReaderFromChanByteSliceChunked(ch, nil))
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	want := "6\r\nbefore\r\n5\r\nafter\r\n0\r\n\r\n"
+	if string(got) != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+// TestReaderFromChanByteSliceChunkedRejectsTrailerInjection guards against
+// a caller-supplied trailer value smuggling an extra trailer/header line
+// into the stream via embedded CRLF -- the same class of bug as HTTP
+// response splitting.
+func TestReaderFromChanByteSliceChunkedRejectsTrailerInjection(t *testing.T) {
+	ch := make(chan []byte, 1)
+	ch <- []byte("hi")
+	close(ch)
+
+	_, err := io.ReadAll(ReaderFromChanByteSliceChunked(ch, &ChunkedOptions{
+		Trailer: map[string]string{"X-Evil": "ok\r\nSmuggled-Header: pwned"},
+	}))
+	if err == nil {
+		t.Fatalf("expected an error for a trailer value containing CRLF")
+	}
+}
+
+func TestReaderFromChanByteSliceChunkedRejectsTrailerNameWithColon(t *testing.T) {
+	ch := make(chan []byte, 1)
+	ch <- []byte("hi")
+	close(ch)
+
+	_, err := io.ReadAll(ReaderFromChanByteSliceChunked(ch, &ChunkedOptions{
+		Trailer: map[string]string{"X-Evil:Injected": "ok"},
+	}))
+	if err == nil {
+		t.Fatalf("expected an error for a trailer name containing ':'")
+	}
+}
+
+func TestReaderFromChanStringChunked(t *testing.T) {
+	ch := make(chan string, 2)
+	ch <- "hello"
+	ch <- "world"
+	close(ch)
+
+	got, err := io.ReadAll(ReaderFromChanStringChunked(ch, nil))
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	want := "5\r\nhello\r\n5\r\nworld\r\n0\r\n\r\n"
+	if string(got) != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2013 Eric Myhre
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iox
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+/*
+	ReaderFromChanStringContext is like ReaderFromChanReadonlyString, but
+	every Read also selects on ctx.Done(); if the context is canceled or its
+	deadline expires before a message arrives, Read returns 0 and an error
+	wrapping ctx.Err() (so errors.Is(err, context.Canceled) and
+	errors.Is(err, context.DeadlineExceeded) both work), instead of blocking
+	forever on a producer that has stalled or died.
+*/
+func ReaderFromChanStringContext(ctx context.Context, ch <-chan string) io.Reader {
+	return &readerChanStringContext{ctx: ctx, ch: ch}
+}
+
+type readerChanStringContext struct {
+	ctx     context.Context
+	ch      <-chan string
+	buf     []byte
+	timeout *readDeadline
+}
+
+func (r *readerChanStringContext) Read(p []byte) (n int, err error) {
+	if len(r.buf) > 0 {
+		n = copy(p, r.buf)
+		r.buf = r.buf[n:]
+		return n, nil
+	}
+
+	timer, stop := r.timeout.arm()
+	defer stop()
+
+	select {
+	case str, open := <-r.ch:
+		bytes := []byte(str)
+		w := copy(p, bytes)
+		r.buf = bytes[w:]
+		if open || len(r.buf) > 0 {
+			return w, nil
+		}
+		return w, io.EOF
+	case <-timer:
+		return 0, os.ErrDeadlineExceeded
+	case <-r.ctx.Done():
+		return 0, fmt.Errorf("iox: read canceled: %w", r.ctx.Err())
+	}
+}
+
+// SetReadDeadline arranges for future Reads to fail with
+// os.ErrDeadlineExceeded if no message arrives within d. Passing d <= 0
+// clears any previously set deadline.
+func (r *readerChanStringContext) SetReadDeadline(d time.Duration) {
+	r.timeout = newReadDeadline(d)
+}
+
+func ReaderFromChanByteSliceContext(ctx context.Context, ch <-chan []byte) io.Reader {
+	return &readerChanByteSliceContext{ctx: ctx, ch: ch}
+}
+
+type readerChanByteSliceContext struct {
+	ctx     context.Context
+	ch      <-chan []byte
+	buf     []byte
+	timeout *readDeadline
+}
+
+func (r *readerChanByteSliceContext) Read(p []byte) (n int, err error) {
+	if len(r.buf) > 0 {
+		n = copy(p, r.buf)
+		r.buf = r.buf[n:]
+		return n, nil
+	}
+
+	timer, stop := r.timeout.arm()
+	defer stop()
+
+	select {
+	case bats, open := <-r.ch:
+		w := copy(p, bats)
+		r.buf = bats[w:]
+		if open || len(r.buf) > 0 {
+			return w, nil
+		}
+		return w, io.EOF
+	case <-timer:
+		return 0, os.ErrDeadlineExceeded
+	case <-r.ctx.Done():
+		return 0, fmt.Errorf("iox: read canceled: %w", r.ctx.Err())
+	}
+}
+
+// SetReadDeadline arranges for future Reads to fail with
+// os.ErrDeadlineExceeded if no message arrives within d. Passing d <= 0
+// clears any previously set deadline.
+func (r *readerChanByteSliceContext) SetReadDeadline(d time.Duration) {
+	r.timeout = newReadDeadline(d)
+}
+
+// readDeadline holds the per-Read timeout configured via SetReadDeadline. A
+// nil *readDeadline (the zero value of the pointer) means no deadline is
+// set, in which case arm returns a channel that never fires.
+type readDeadline struct {
+	d time.Duration
+}
+
+func newReadDeadline(d time.Duration) *readDeadline {
+	if d <= 0 {
+		return nil
+	}
+	return &readDeadline{d: d}
+}
+
+// arm starts a timer for this deadline, if any, and returns a channel that
+// fires when it expires along with a cleanup func that must be deferred.
+func (rd *readDeadline) arm() (<-chan time.Time, func()) {
+	if rd == nil {
+		return nil, func() {}
+	}
+	t := time.NewTimer(rd.d)
+	return t.C, func() { t.Stop() }
+}
@@ -0,0 +1,107 @@
+// Copyright 2013 Eric Myhre
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iox
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestReaderFromChanStringContextReadsNormally(t *testing.T) {
+	ch := make(chan string, 2)
+	ch <- "hello "
+	ch <- "world"
+	close(ch)
+
+	r := ReaderFromChanStringContext(context.Background(), ch)
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if string(got) != "hello world" {
+		t.Fatalf("got %q, want %q", got, "hello world")
+	}
+}
+
+func TestReaderFromChanStringContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan string) // never sent to
+	r := ReaderFromChanStringContext(ctx, ch)
+
+	cancel()
+
+	_, err := r.Read(make([]byte, 16))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got err %v, want errors.Is(err, context.Canceled)", err)
+	}
+}
+
+func TestReaderFromChanByteSliceContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan []byte) // never sent to
+	r := ReaderFromChanByteSliceContext(ctx, ch)
+
+	cancel()
+
+	_, err := r.Read(make([]byte, 16))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got err %v, want errors.Is(err, context.Canceled)", err)
+	}
+}
+
+func TestReaderFromChanStringContextReadDeadline(t *testing.T) {
+	ch := make(chan string) // never sent to
+	r := &readerChanStringContext{ctx: context.Background(), ch: ch}
+	r.SetReadDeadline(10 * time.Millisecond)
+
+	_, err := r.Read(make([]byte, 16))
+	if !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Fatalf("got err %v, want os.ErrDeadlineExceeded", err)
+	}
+}
+
+func TestReaderFromChanByteSliceContextReadDeadline(t *testing.T) {
+	ch := make(chan []byte) // never sent to
+	r := &readerChanByteSliceContext{ctx: context.Background(), ch: ch}
+	r.SetReadDeadline(10 * time.Millisecond)
+
+	_, err := r.Read(make([]byte, 16))
+	if !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Fatalf("got err %v, want os.ErrDeadlineExceeded", err)
+	}
+}
+
+func TestReaderFromChanStringContextUnblocksOnSend(t *testing.T) {
+	ctx := context.Background()
+	ch := make(chan string)
+	r := ReaderFromChanStringContext(ctx, ch)
+
+	go func() {
+		ch <- "hi"
+		close(ch)
+	}()
+
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if string(got) != "hi" {
+		t.Fatalf("got %q, want %q", got, "hi")
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2013 Eric Myhre
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iox
+
+import (
+	"encoding/binary"
+	"io"
+	"strconv"
+)
+
+/*
+	FramingMode selects how ReaderFromChanByteSliceFramed delimits the
+	messages it reads off its channel, so that message boundaries -- which
+	Read may otherwise split or merge arbitrarily -- survive the trip across
+	the io.Reader interface.
+*/
+type FramingMode interface {
+	frame(msg []byte) []byte
+}
+
+// FrameLengthPrefix32 prefixes each payload with its length as a big-endian
+// uint32.
+var FrameLengthPrefix32 FramingMode = frameLengthPrefix32{}
+
+type frameLengthPrefix32 struct{}
+
+func (frameLengthPrefix32) frame(msg []byte) []byte {
+	out := make([]byte, 4+len(msg))
+	binary.BigEndian.PutUint32(out, uint32(len(msg)))
+	copy(out[4:], msg)
+	return out
+}
+
+// FrameLengthPrefixVarint prefixes each payload with its length as a
+// protobuf-style unsigned varint.
+var FrameLengthPrefixVarint FramingMode = frameLengthPrefixVarint{}
+
+type frameLengthPrefixVarint struct{}
+
+func (frameLengthPrefixVarint) frame(msg []byte) []byte {
+	prefix := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(prefix, uint64(len(msg)))
+	out := make([]byte, 0, n+len(msg))
+	out = append(out, prefix[:n]...)
+	out = append(out, msg...)
+	return out
+}
+
+// FrameDelimited appends delim after each payload, e.g. a newline for
+// line-delimited JSON or a NUL byte for NUL-delimited records.
+func FrameDelimited(delim []byte) FramingMode {
+	return frameDelimited{delim: delim}
+}
+
+type frameDelimited struct {
+	delim []byte
+}
+
+func (f frameDelimited) frame(msg []byte) []byte {
+	out := make([]byte, 0, len(msg)+len(f.delim))
+	out = append(out, msg...)
+	out = append(out, f.delim...)
+	return out
+}
+
+// FrameNetstring formats each payload as a DJB netstring: "len:payload,".
+var FrameNetstring FramingMode = frameNetstring{}
+
+type frameNetstring struct{}
+
+func (frameNetstring) frame(msg []byte) []byte {
+	size := strconv.Itoa(len(msg))
+	out := make([]byte, 0, len(size)+1+len(msg)+1)
+	out = append(out, size...)
+	out = append(out, ':')
+	out = append(out, msg...)
+	out = append(out, ',')
+	return out
+}
+
+/*
+	ReaderFromChanByteSliceFramed is like ReaderFromChanByteSlice, but wraps
+	every message read off the channel in the framing described by mode
+	before handing it to the reader, so a consumer that understands the
+	framing can recover exactly the byte ranges that were sent -- unlike
+	plain ReaderFromChanByteSlice, where consecutive Reads may coalesce or
+	split messages with no way to tell where one ended and the next began.
+*/
+func ReaderFromChanByteSliceFramed(ch <-chan []byte, mode FramingMode) io.Reader {
+	return &readerChanFramed{ch: ch, mode: mode}
+}
+
+type readerChanFramed struct {
+	ch   <-chan []byte
+	mode FramingMode
+	buf  []byte
+}
+
+func (r *readerChanFramed) Read(p []byte) (n int, err error) {
+	if len(r.buf) == 0 {
+		msg, open := <-r.ch
+		if !open {
+			return 0, io.EOF
+		}
+		r.buf = r.mode.frame(msg)
+	}
+	n = copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
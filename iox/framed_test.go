@@ -0,0 +1,76 @@
+// Copyright 2013 Eric Myhre
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iox
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestReaderFromChanByteSliceFramed(t *testing.T) {
+	tests := []struct {
+		name string
+		mode FramingMode
+		want string
+	}{
+		{"LengthPrefix32", FrameLengthPrefix32, "\x00\x00\x00\x05hello\x00\x00\x00\x05world"},
+		{"LengthPrefixVarint", FrameLengthPrefixVarint, "\x05hello\x05world"},
+		{"Delimited", FrameDelimited([]byte("\n")), "hello\nworld\n"},
+		{"Netstring", FrameNetstring, "5:hello,5:world,"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch := make(chan []byte, 2)
+			ch <- []byte("hello")
+			ch <- []byte("world")
+			close(ch)
+
+			r := ReaderFromChanByteSliceFramed(ch, tt.mode)
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("ReadAll: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReaderFromChanByteSliceFramedSmallBuffer(t *testing.T) {
+	ch := make(chan []byte, 2)
+	ch <- []byte("hello")
+	ch <- []byte("world")
+	close(ch)
+
+	r := ReaderFromChanByteSliceFramed(ch, FrameNetstring)
+	var buf bytes.Buffer
+	tmp := make([]byte, 3)
+	for {
+		n, err := r.Read(tmp)
+		buf.Write(tmp[:n])
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read: %v", err)
+		}
+	}
+	want := "5:hello,5:world,"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright 2013 Eric Myhre
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iox
+
+import (
+	"io"
+	"io/fs"
+)
+
+/*
+	ReaderFromFS opens name against fsys and returns it as an io.ReadCloser.
+
+	This lets a gosh pipeline source its input from any io/fs.FS -- an
+	embed.FS, a zip.Reader, an os.DirFS, or a third-party filesystem
+	abstraction such as afero's (afero.Fs values that also implement
+	io/fs.FS, or the result of afero.NewIOFS, work here without any special
+	casing) -- without the caller having to open the file itself and
+	remember to Close it at the right time.
+*/
+func ReaderFromFS(fsys fs.FS, name string) (io.ReadCloser, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
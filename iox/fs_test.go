@@ -0,0 +1,86 @@
+// Copyright 2013 Eric Myhre
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iox
+
+import (
+	"io"
+	"testing/fstest"
+	"testing"
+)
+
+func TestReaderFromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"greeting.txt": &fstest.MapFile{Data: []byte("hello, fs")},
+	}
+
+	rc, err := ReaderFromFS(fsys, "greeting.txt")
+	if err != nil {
+		t.Fatalf("ReaderFromFS: %v", err)
+	}
+	defer rc.Close()
+
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if string(got) != "hello, fs" {
+		t.Fatalf("got %q, want %q", got, "hello, fs")
+	}
+}
+
+func TestReaderFromFSMissingFile(t *testing.T) {
+	fsys := fstest.MapFS{}
+
+	if _, err := ReaderFromFS(fsys, "nope.txt"); err == nil {
+		t.Fatalf("expected an error for a missing file")
+	}
+}
+
+// TestReaderFromInterfaceAcceptsFSFile confirms that fs.File values -- and,
+// by the same structural reasoning, afero.File values -- are already
+// handled by ReaderFromInterface's io.Reader case without any special
+// casing.
+func TestReaderFromInterfaceAcceptsFSFile(t *testing.T) {
+	fsys := fstest.MapFS{
+		"greeting.txt": &fstest.MapFile{Data: []byte("hello, fs")},
+	}
+	f, err := fsys.Open("greeting.txt")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer f.Close()
+
+	r := ReaderFromInterface(f)
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if string(got) != "hello, fs" {
+		t.Fatalf("got %q, want %q", got, "hello, fs")
+	}
+}
+
+func TestReaderFromInterfacePanicsOnUnsupportedType(t *testing.T) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatalf("expected a panic")
+		}
+		if _, ok := r.(ReaderUnrefinableFromInterface); !ok {
+			t.Fatalf("got panic of type %T, want ReaderUnrefinableFromInterface", r)
+		}
+	}()
+	ReaderFromInterface(42)
+}
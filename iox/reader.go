@@ -35,6 +35,11 @@ import (
 		chan string
 		chan []byte
 
+	Since fs.File and afero.File both satisfy io.Reader, values of those
+	types are already handled by the io.Reader case above; use
+	ReaderFromFS(fsys, name) to open a path against an io/fs.FS (or an
+	afero.Fs exposed as one) and get an io.ReadCloser in one step.
+
 	An error of type ReaderUnrefinableFromInterface is thrown if an argument
 	of any other type is given.
 */
@@ -61,6 +66,16 @@ func ReaderFromInterface(x interface{}) io.Reader {
 	}
 }
 
+// ReaderUnrefinableFromInterface is the error type panicked by
+// ReaderFromInterface when given an argument of unsupported type.
+type ReaderUnrefinableFromInterface struct {
+	wat interface{}
+}
+
+func (e ReaderUnrefinableFromInterface) Error() string {
+	return "iox: cannot produce an io.Reader from this type"
+}
+
 func ReaderFromString(str string) io.Reader {
 	return strings.NewReader(str)
 }
@@ -89,13 +104,13 @@ func (r *readerChanString) Read(p []byte) (n int, err error) {
 	} else {
 		// not room for the whole buffer; copy what there's room for, shift buf, return.
 		w = copy(p, r.buf[:len(p)])
-		r.buf = r.buf[len(p):0]
+		r.buf = r.buf[len(p):]
 		return w, nil
 	}
 
 	str, open := <-r.ch
 	bytes := []byte(str)
-	w2 := copy(p, bytes)
+	w2 := copy(p[w:], bytes)
 	r.buf = bytes[w2:]
 
 	if open || len(r.buf) > 0 {
@@ -130,13 +145,13 @@ func (r *readerChanReadonlyString) Read(p []byte) (n int, err error) {
 	} else {
 		// not room for the whole buffer; copy what there's room for, shift buf, return.
 		w = copy(p, r.buf[:len(p)])
-		r.buf = r.buf[len(p):0]
+		r.buf = r.buf[len(p):]
 		return w, nil
 	}
 
 	str, open := <-r.ch
 	bytes := []byte(str)
-	w2 := copy(p, bytes)
+	w2 := copy(p[w:], bytes)
 	r.buf = bytes[w2:]
 
 	if open || len(r.buf) > 0 {
@@ -166,12 +181,12 @@ func (r *readerChanByteSlice) Read(p []byte) (n int, err error) {
 	} else {
 		// not room for the whole buffer; copy what there's room for, shift buf, return.
 		w = copy(p, r.buf[:len(p)])
-		r.buf = r.buf[len(p):0]
+		r.buf = r.buf[len(p):]
 		return w, nil
 	}
 
 	bytes, open := <-r.ch
-	w2 := copy(p, bytes)
+	w2 := copy(p[w:], bytes)
 	r.buf = bytes[w2:]
 
 	if open || len(r.buf) > 0 {
@@ -206,12 +221,12 @@ func (r *readerChanReadonlyByteSlice) Read(p []byte) (n int, err error) {
 	} else {
 		// not room for the whole buffer; copy what there's room for, shift buf, return.
 		w = copy(p, r.buf[:len(p)])
-		r.buf = r.buf[len(p):0]
+		r.buf = r.buf[len(p):]
 		return w, nil
 	}
 
 	bytes, open := <-r.ch
-	w2 := copy(p, bytes)
+	w2 := copy(p[w:], bytes)
 	r.buf = bytes[w2:]
 
 	if open || len(r.buf) > 0 {
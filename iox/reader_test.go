@@ -0,0 +1,179 @@
+// Copyright 2013 Eric Myhre
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iox
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+	"testing/iotest"
+)
+
+// reader factories under test, each building a reader that, fully drained,
+// should produce exactly the bytes it was given.
+var channelReaderCases = []struct {
+	name string
+	mk   func(want []byte) io.Reader
+}{
+	{"ChanString", func(want []byte) io.Reader {
+		return readerFromChunkedChanString(want)
+	}},
+	{"ChanReadonlyString", func(want []byte) io.Reader {
+		ch := make(chan string, 1)
+		go feedChanString(ch, want)
+		return ReaderFromChanReadonlyString(ch)
+	}},
+	{"ChanByteSlice", func(want []byte) io.Reader {
+		ch := make(chan []byte, 1)
+		go feedChanByteSlice(ch, want)
+		return ReaderFromChanByteSlice(ch)
+	}},
+	{"ChanReadonlyByteSlice", func(want []byte) io.Reader {
+		ch := make(chan []byte, 1)
+		go feedChanByteSlice(ch, want)
+		return ReaderFromChanReadonlyByteSlice(ch)
+	}},
+}
+
+// readerFromChunkedChanString feeds `want` through ReaderFromChanString in
+// several separate sends, so the reader's internal buffering is exercised.
+func readerFromChunkedChanString(want []byte) io.Reader {
+	ch := make(chan string)
+	go feedChanString(ch, want)
+	return ReaderFromChanString(ch)
+}
+
+func feedChanString(ch chan string, want []byte) {
+	for _, part := range splitRandomly(want) {
+		ch <- string(part)
+	}
+	close(ch)
+}
+
+func feedChanByteSlice(ch chan []byte, want []byte) {
+	for _, part := range splitRandomly(want) {
+		ch <- part
+	}
+	close(ch)
+}
+
+// splitRandomly breaks b into a handful of arbitrarily-sized pieces, so
+// tests exercise reads that don't land on message boundaries.
+func splitRandomly(b []byte) [][]byte {
+	if len(b) == 0 {
+		return [][]byte{b}
+	}
+	var parts [][]byte
+	for len(b) > 0 {
+		n := 1 + rand.Intn(len(b))
+		parts = append(parts, b[:n])
+		b = b[n:]
+	}
+	return parts
+}
+
+// TestChannelReadersConformToReaderContract is a reusable conformance suite:
+// any future reader added to channelReaderCases gets the same coverage for
+// free. The TestReader subtest drives each reader through the stdlib's own
+// testing/iotest.TestReader conformance checker, which is what actually
+// caught the buffer-corruption regressions in this package.
+func TestChannelReadersConformToReaderContract(t *testing.T) {
+	want := []byte("the quick brown fox jumps over the lazy dog, repeatedly, to fill more than one buffer's worth of bytes")
+	for _, tt := range channelReaderCases {
+		t.Run(tt.name+"/OneByteAtATime", func(t *testing.T) {
+			r := iotest.OneByteReader(tt.mk(want))
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("ReadAll: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Fatalf("got %q, want %q", got, want)
+			}
+		})
+		t.Run(tt.name+"/SmallBuffer", func(t *testing.T) {
+			r := tt.mk(want)
+			var buf bytes.Buffer
+			tmp := make([]byte, 3)
+			for {
+				n, err := r.Read(tmp)
+				buf.Write(tmp[:n])
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Read: %v", err)
+				}
+			}
+			if !bytes.Equal(buf.Bytes(), want) {
+				t.Fatalf("got %q, want %q", buf.Bytes(), want)
+			}
+		})
+		t.Run(tt.name+"/TestReader", func(t *testing.T) {
+			if err := iotest.TestReader(tt.mk(want), want); err != nil {
+				t.Fatalf("iotest.TestReader: %v", err)
+			}
+		})
+		t.Run(tt.name+"/ReadAll", func(t *testing.T) {
+			got, err := io.ReadAll(tt.mk(want))
+			if err != nil {
+				t.Fatalf("ReadAll: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Fatalf("got %q, want %q", got, want)
+			}
+		})
+		t.Run(tt.name+"/Copy", func(t *testing.T) {
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, tt.mk(want)); err != nil {
+				t.Fatalf("Copy: %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), want) {
+				t.Fatalf("got %q, want %q", buf.Bytes(), want)
+			}
+		})
+	}
+}
+
+// TestChannelReadersRandomizedReadSizes fuzzes the size of the buffer passed
+// to Read on every call, which is what catches the r.buf[len(p):0] class of
+// bug: any read that doesn't consume the whole internal buffer must retain
+// the remainder, not truncate it to nothing.
+func TestChannelReadersRandomizedReadSizes(t *testing.T) {
+	want := make([]byte, 5000)
+	if _, err := rand.Read(want); err != nil {
+		t.Fatalf("rand.Read: %v", err)
+	}
+	for _, tt := range channelReaderCases {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tt.mk(want)
+			var buf bytes.Buffer
+			for {
+				tmp := make([]byte, 1+rand.Intn(17))
+				n, err := r.Read(tmp)
+				buf.Write(tmp[:n])
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Read: %v", err)
+				}
+			}
+			if !bytes.Equal(buf.Bytes(), want) {
+				t.Fatalf("randomized read sizes dropped or corrupted bytes: got %d bytes, want %d", buf.Len(), len(want))
+			}
+		})
+	}
+}
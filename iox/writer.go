@@ -0,0 +1,124 @@
+// Copyright 2013 Eric Myhre
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iox
+
+import (
+	"bytes"
+	"io"
+)
+
+/*
+	Converts any of a range of data sinks to an io.Writer interface, or
+	an io.WriteCloser if appropriate.
+
+	Writers will be produced from:
+		io.Writer
+		*bytes.Buffer
+		*[]byte
+		chan string
+		chan []byte
+	WriteClosers will be produced from:
+		chan string
+		chan []byte
+
+	An error of type WriterUnrefinableFromInterface is thrown if an argument
+	of any other type is given.
+*/
+func WriterFromInterface(x interface{}) io.Writer {
+	switch y := x.(type) {
+	case io.Writer:
+		return y
+	case *bytes.Buffer:
+		return WriterFromBuffer(y)
+	case *[]byte:
+		return WriterFromByteSlice(y)
+	case chan string:
+		return WriterFromChanString(y)
+	case chan []byte:
+		return WriterFromChanByteSlice(y)
+	default:
+		panic(WriterUnrefinableFromInterface{wat: y})
+	}
+}
+
+// WriterFromBuffer returns buf itself, since *bytes.Buffer already
+// implements io.Writer; it exists for symmetry with the rest of this API.
+func WriterFromBuffer(buf *bytes.Buffer) io.Writer {
+	return buf
+}
+
+// WriterFromByteSlice returns an io.Writer that appends every write to
+// *bats, growing it as necessary.
+func WriterFromByteSlice(bats *[]byte) io.Writer {
+	return &writerByteSlice{bats: bats}
+}
+
+type writerByteSlice struct {
+	bats *[]byte
+}
+
+func (w *writerByteSlice) Write(p []byte) (n int, err error) {
+	*w.bats = append(*w.bats, p...)
+	return len(p), nil
+}
+
+func WriterFromChanString(ch chan string) io.WriteCloser {
+	return &writerChanString{ch: ch}
+}
+
+type writerChanString struct {
+	ch chan string
+}
+
+func (w *writerChanString) Write(p []byte) (n int, err error) {
+	w.ch <- string(p)
+	return len(p), nil
+}
+
+func (w *writerChanString) Close() error {
+	close(w.ch)
+	return nil
+}
+
+func WriterFromChanByteSlice(ch chan []byte) io.WriteCloser {
+	return &writerChanByteSlice{ch: ch}
+}
+
+type writerChanByteSlice struct {
+	ch chan []byte
+}
+
+func (w *writerChanByteSlice) Write(p []byte) (n int, err error) {
+	// copy p, since the caller may reuse or mutate its buffer after Write returns.
+	bats := make([]byte, len(p))
+	copy(bats, p)
+	w.ch <- bats
+	return len(p), nil
+}
+
+func (w *writerChanByteSlice) Close() error {
+	close(w.ch)
+	return nil
+}
+
+// WriterUnrefinableFromInterface is the error type panicked by
+// WriterFromInterface when given an argument of unsupported type.
+type WriterUnrefinableFromInterface struct {
+	wat interface{}
+}
+
+func (e WriterUnrefinableFromInterface) Error() string {
+	return "iox: cannot produce an io.Writer from this type"
+}
@@ -0,0 +1,96 @@
+// Copyright 2013 Eric Myhre
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iox
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriterFromByteSlice(t *testing.T) {
+	var bats []byte
+	w := WriterFromByteSlice(&bats)
+	if _, err := w.Write([]byte("hello ")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if _, err := w.Write([]byte("world")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if string(bats) != "hello world" {
+		t.Fatalf("got %q, want %q", bats, "hello world")
+	}
+}
+
+func TestWriterFromBuffer(t *testing.T) {
+	var buf bytes.Buffer
+	w := WriterFromBuffer(&buf)
+	if _, err := w.Write([]byte("hello")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if buf.String() != "hello" {
+		t.Fatalf("got %q, want %q", buf.String(), "hello")
+	}
+}
+
+func TestWriterFromChanString(t *testing.T) {
+	ch := make(chan string, 2)
+	w := WriterFromChanString(ch)
+	if _, err := w.Write([]byte("hello")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if got := <-ch; got != "hello" {
+		t.Fatalf("got %q, want %q", got, "hello")
+	}
+	if _, open := <-ch; open {
+		t.Fatalf("channel should be closed after Close")
+	}
+}
+
+func TestWriterFromChanByteSlice(t *testing.T) {
+	ch := make(chan []byte, 2)
+	w := WriterFromChanByteSlice(ch)
+	p := []byte("hello")
+	if _, err := w.Write(p); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	// mutate the caller's buffer after Write returns, to prove the writer
+	// copied it rather than retaining a reference.
+	copy(p, "XXXXX")
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if got := string(<-ch); got != "hello" {
+		t.Fatalf("got %q, want %q", got, "hello")
+	}
+	if _, open := <-ch; open {
+		t.Fatalf("channel should be closed after Close")
+	}
+}
+
+func TestWriterFromInterfacePanicsOnUnsupportedType(t *testing.T) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatalf("expected a panic")
+		}
+		if _, ok := r.(WriterUnrefinableFromInterface); !ok {
+			t.Fatalf("got panic of type %T, want WriterUnrefinableFromInterface", r)
+		}
+	}()
+	WriterFromInterface(42)
+}